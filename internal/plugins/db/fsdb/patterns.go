@@ -3,6 +3,7 @@ package fsdb
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -13,11 +14,121 @@ import (
 
 const inputSentinel = "__FABRIC_INPUT_SENTINEL_TOKEN__"
 
+// globSuffix marks a source as matching its prefix and everything below it,
+// mirroring the "..." convention used by cmd/go's package pattern matcher.
+const globSuffix = "..."
+
+// PatternNotFoundError is returned when source names a specific pattern that
+// does not exist in any known location.
+type PatternNotFoundError struct {
+	Name string
+}
+
+func (e *PatternNotFoundError) Error() string {
+	return fmt.Sprintf("pattern %q not found", e.Name)
+}
+
+// NoPatternMatchError is returned when source is a syntactically valid glob
+// that does not match any known pattern name, so callers can distinguish
+// "no match" from "not found".
+type NoPatternMatchError struct {
+	Source string
+}
+
+func (e *NoPatternMatchError) Error() string {
+	return fmt.Sprintf("no patterns match %q", e.Source)
+}
+
+// isGlobSource reports whether source contains glob metacharacters or the
+// "..." recursive-prefix suffix, as opposed to naming a single pattern.
+func isGlobSource(source string) bool {
+	return strings.HasSuffix(source, globSuffix) || strings.ContainsAny(source, "*?[")
+}
+
+// Overlay is a single root in the ordered list of pattern directories layered
+// on top of the main patterns directory. IncludePatterns and ExcludePatterns
+// are glob lists (path.Match semantics) evaluated against the pattern name;
+// an empty IncludePatterns allows every name through the include gate.
+type Overlay struct {
+	Dir             string
+	IncludePatterns []string
+	ExcludePatterns []string
+}
+
+// allows reports whether name passes this overlay's include/exclude filters.
+func (ov Overlay) allows(name string) bool {
+	if len(ov.IncludePatterns) > 0 {
+		var included bool
+		for _, pattern := range ov.IncludePatterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+
+	for _, pattern := range ov.ExcludePatterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return false
+		}
+	}
+
+	return true
+}
+
 type PatternsEntity struct {
 	*StorageEntity
 	SystemPatternFile      string
 	UniquePatternsFilePath string
-	CustomPatternsDir      string
+
+	// CustomPatternsDir is kept as a shim over Overlays for callers that
+	// only need a single extra directory: it behaves as a one-entry
+	// overlay list with no include/exclude filters. New callers that need
+	// layering or filtering should set Overlays directly.
+	CustomPatternsDir string
+
+	// Overlays is an ordered list of pattern directories layered on top of
+	// the main patterns directory. Later overlays override earlier ones
+	// for a given name, but only when that overlay's filters allow the
+	// name through.
+	Overlays []Overlay
+
+	// LiveReload enables the fsnotify-backed watcher started by Subscribe.
+	// getFromDB and getFromFile already read straight from disk on every
+	// call, so this exists purely to notify listeners (chatter, REST, web)
+	// who cache names or content upstream, so they can invalidate on edit.
+	LiveReload bool
+
+	watcher *patternWatcher
+}
+
+// NewPatternsEntityWithOverlays builds a PatternsEntity backed by an ordered
+// list of overlay directories instead of (or in addition to) a single
+// CustomPatternsDir.
+func NewPatternsEntityWithOverlays(
+	storage *StorageEntity, systemPatternFile, uniquePatternsFilePath string, overlays []Overlay,
+) *PatternsEntity {
+	return &PatternsEntity{
+		StorageEntity:          storage,
+		SystemPatternFile:      systemPatternFile,
+		UniquePatternsFilePath: uniquePatternsFilePath,
+		Overlays:               overlays,
+	}
+}
+
+// effectiveOverlays returns Overlays if set, otherwise falls back to the
+// legacy CustomPatternsDir shimmed into a single unfiltered overlay.
+func (o *PatternsEntity) effectiveOverlays() []Overlay {
+	if len(o.Overlays) > 0 {
+		return o.Overlays
+	}
+	if o.CustomPatternsDir != "" {
+		return []Overlay{{Dir: o.CustomPatternsDir}}
+	}
+	return nil
 }
 
 // Pattern represents a single pattern with its metadata
@@ -25,6 +136,11 @@ type Pattern struct {
 	Name        string
 	Description string
 	Pattern     string
+
+	// Files holds the rendered non-main files of a pattern bundle, as
+	// populated by GetBundle. It is empty for single-file patterns loaded
+	// through GetApplyVariables/GetManyApplyVariables.
+	Files []RenderedFile
 }
 
 // GetApplyVariables main entry point for getting patterns from any source
@@ -39,6 +155,157 @@ func (o *PatternsEntity) GetApplyVariables(
 	return
 }
 
+// GetManyApplyVariables resolves source as a glob (e.g. "analyze_*", the
+// recursive "write/**", or the Go-style "summarize/..." which, like
+// cmd/go's dir/... , also matches "summarize" itself) against the union of
+// known pattern names and returns every match with variables applied, in
+// deterministic (sorted) order. A "/" or "."-prefixed source is instead
+// expanded with filepath.Glob, so "./mypatterns/*.md" works against the
+// filesystem directly. If source is not a glob, it behaves like
+// GetApplyVariables but always returns a single-element slice.
+func (o *PatternsEntity) GetManyApplyVariables(
+	source string, variables map[string]string, input string) (patterns []*Pattern, err error) {
+
+	if isFilePathSource(source) {
+		var matches []string
+		if matches, err = o.globFiles(source); err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, &NoPatternMatchError{Source: source}
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			var pattern *Pattern
+			if pattern, err = o.getFromFile(match); err != nil {
+				return nil, err
+			}
+			if err = o.applyVariables(pattern, variables, input); err != nil {
+				return nil, err
+			}
+			patterns = append(patterns, pattern)
+		}
+		return
+	}
+
+	if !isGlobSource(source) {
+		var pattern *Pattern
+		if pattern, err = o.GetApplyVariables(source, variables, input); err != nil {
+			return nil, err
+		}
+		return []*Pattern{pattern}, nil
+	}
+
+	var names []string
+	if names, err = o.resolveGlobNames(source); err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		var pattern *Pattern
+		if pattern, err = o.getFromDB(name); err != nil {
+			return nil, err
+		}
+		if err = o.applyVariables(pattern, variables, input); err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	return
+}
+
+// resolveGlobNames expands source against the union of names returned by
+// GetNames. Each "/"-separated segment of source is matched against the
+// corresponding segment of the name with path.Match ("*"/"?"/"[…]"), except
+// for a literal "**" segment, which (as in "write/**") matches zero or more
+// whole name segments, crossing "/" the way a single "*" cannot. A "..."
+// suffix means "this prefix and everything below it, including the prefix
+// itself", mirroring cmd/go's dir/... package pattern. It returns
+// NoPatternMatchError if source is a syntactically valid glob that matches
+// nothing.
+func (o *PatternsEntity) resolveGlobNames(source string) (matches []string, err error) {
+	var names []string
+	if names, err = o.GetNames(); err != nil {
+		return nil, err
+	}
+
+	if prefix, ok := strings.CutSuffix(source, globSuffix); ok {
+		prefix = strings.TrimSuffix(prefix, "/")
+		for _, name := range names {
+			if name == prefix || strings.HasPrefix(name, prefix+"/") {
+				matches = append(matches, name)
+			}
+		}
+	} else {
+		patternSegments := strings.Split(source, "/")
+		for _, name := range names {
+			var ok bool
+			if ok, err = globMatchSegments(patternSegments, strings.Split(name, "/")); err != nil {
+				return nil, fmt.Errorf("invalid pattern glob %q: %w", source, err)
+			}
+			if ok {
+				matches = append(matches, name)
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, &NoPatternMatchError{Source: source}
+	}
+
+	sort.Strings(matches)
+	return
+}
+
+// globMatchSegments matches patternSegments against nameSegments
+// segment-by-segment with path.Match, treating a literal "**" pattern
+// segment as matching zero or more whole name segments.
+func globMatchSegments(patternSegments, nameSegments []string) (bool, error) {
+	if len(patternSegments) == 0 {
+		return len(nameSegments) == 0, nil
+	}
+
+	if patternSegments[0] == "**" {
+		if ok, err := globMatchSegments(patternSegments[1:], nameSegments); err != nil || ok {
+			return ok, err
+		}
+		if len(nameSegments) == 0 {
+			return false, nil
+		}
+		return globMatchSegments(patternSegments, nameSegments[1:])
+	}
+
+	if len(nameSegments) == 0 {
+		return false, nil
+	}
+
+	ok, err := path.Match(patternSegments[0], nameSegments[0])
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return globMatchSegments(patternSegments[1:], nameSegments[1:])
+}
+
+// globFiles expands a "/"- or "."-prefixed source as a filesystem glob,
+// falling back to treating it as a literal path when it contains no
+// metacharacters.
+func (o *PatternsEntity) globFiles(source string) (matches []string, err error) {
+	absPath, err := util.GetAbsolutePath(source)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve file path: %v", err)
+	}
+
+	if !isGlobSource(source) {
+		return []string{absPath}, nil
+	}
+
+	if matches, err = filepath.Glob(absPath); err != nil {
+		return nil, fmt.Errorf("invalid file glob %q: %v", source, err)
+	}
+	return
+}
+
 // GetWithoutVariables returns a pattern with only the {{input}} placeholder processed
 // and skips template variable replacement
 func (o *PatternsEntity) GetWithoutVariables(source, input string) (pattern *Pattern, err error) {
@@ -51,28 +318,50 @@ func (o *PatternsEntity) GetWithoutVariables(source, input string) (pattern *Pat
 	return
 }
 
-func (o *PatternsEntity) loadPattern(source string) (pattern *Pattern, err error) {
-	// Determine if this is a file path
-	isFilePath := strings.HasPrefix(source, "\\") ||
+// isFilePathSource reports whether source names a filesystem path rather than
+// a pattern in the database.
+func isFilePathSource(source string) bool {
+	return strings.HasPrefix(source, "\\") ||
 		strings.HasPrefix(source, "/") ||
 		strings.HasPrefix(source, "~") ||
 		strings.HasPrefix(source, ".")
+}
 
-	if isFilePath {
-		// Resolve the file path using GetAbsolutePath
-		var absPath string
-		if absPath, err = util.GetAbsolutePath(source); err != nil {
-			return nil, fmt.Errorf("could not resolve file path: %v", err)
+func (o *PatternsEntity) loadPattern(source string) (pattern *Pattern, err error) {
+	if isFilePathSource(source) {
+		// Resolve source the same way GetManyApplyVariables does: glob it
+		// against the filesystem (or treat it as a literal path when it has
+		// no metacharacters), then require exactly one match for this
+		// single-pattern call.
+		var matches []string
+		if matches, err = o.globFiles(source); err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, &NoPatternMatchError{Source: source}
 		}
+		if len(matches) > 1 {
+			return nil, fmt.Errorf("file glob %q matches %d files, use GetManyApplyVariables", source, len(matches))
+		}
+		return o.getFromFile(matches[0])
+	}
 
-		// Use the resolved absolute path to get the pattern
-		pattern, _ = o.getFromFile(absPath)
-	} else {
-		// Otherwise, get the pattern from the database
-		pattern, err = o.getFromDB(source)
+	if isGlobSource(source) {
+		// A single-pattern call only accepts a glob when it resolves to
+		// exactly one name; otherwise callers should use
+		// GetManyApplyVariables to get the full set.
+		var names []string
+		if names, err = o.resolveGlobNames(source); err != nil {
+			return nil, err
+		}
+		if len(names) > 1 {
+			return nil, fmt.Errorf("pattern glob %q matches %d patterns, use GetManyApplyVariables", source, len(names))
+		}
+		return o.getFromDB(names[0])
 	}
 
-	return
+	// Otherwise, get the pattern from the database
+	return o.getFromDB(source)
 }
 
 func (o *PatternsEntity) ensureInput(pattern *Pattern) {
@@ -98,10 +387,19 @@ func (o *PatternsEntity) applyVariables(
 	// from recursive variable resolution
 	withSentinel := strings.ReplaceAll(pattern.Pattern, "{{input}}", inputSentinel)
 
+	// Expand {{include "other_pattern"}} directives before generic template
+	// processing, so composed patterns see their includes as plain text.
+	// The sentinel swap above means {{input}} survives the include boundary
+	// untouched, same as any other literal text.
+	var withIncludes string
+	if withIncludes, err = o.expandIncludes(withSentinel, variables, input, nil); err != nil {
+		return
+	}
+
 	// Process all other template variables in the pattern
 	// Pass the actual input so extension calls can use {{input}} within their value parameter
 	var processed string
-	if processed, err = template.ApplyTemplate(withSentinel, variables, input); err != nil {
+	if processed, err = template.ApplyTemplate(withIncludes, variables, input); err != nil {
 		return
 	}
 
@@ -111,26 +409,32 @@ func (o *PatternsEntity) applyVariables(
 	return
 }
 
-// retrieves a pattern from the database by name
+// retrieves a pattern from the database by name, walking overlays in order so
+// that later overlays override earlier ones whenever their filters allow name
+// through
 func (o *PatternsEntity) getFromDB(name string) (ret *Pattern, err error) {
-	// First check custom patterns directory if it exists
-	if o.CustomPatternsDir != "" {
-		customPatternPath := filepath.Join(o.CustomPatternsDir, name, o.SystemPatternFile)
-		if pattern, customErr := os.ReadFile(customPatternPath); customErr == nil {
+	for _, overlay := range o.effectiveOverlays() {
+		if !overlay.allows(name) {
+			continue
+		}
+		overlayPatternPath := filepath.Join(overlay.Dir, name, o.SystemPatternFile)
+		if pattern, overlayErr := os.ReadFile(overlayPatternPath); overlayErr == nil {
 			ret = &Pattern{
 				Name:    name,
 				Pattern: string(pattern),
 			}
-			return ret, nil
 		}
 	}
+	if ret != nil {
+		return ret, nil
+	}
 
 	// Fallback to main patterns directory
 	patternPath := filepath.Join(o.Dir, name, o.SystemPatternFile)
 
 	var pattern []byte
 	if pattern, err = os.ReadFile(patternPath); err != nil {
-		return
+		return nil, &PatternNotFoundError{Name: name}
 	}
 
 	patternStr := string(pattern)
@@ -196,23 +500,26 @@ func (o *PatternsEntity) GetNames() (ret []string, err error) {
 		nameMap[name] = true
 	}
 
-	// Get names from custom patterns directory if it exists
-	if o.CustomPatternsDir != "" {
-		// Create a temporary StorageEntity for the custom directory
-		customStorage := &StorageEntity{
-			Dir:           o.CustomPatternsDir,
+	// Get names from each overlay directory, in order, if any exist
+	for _, overlay := range o.effectiveOverlays() {
+		// Create a temporary StorageEntity for the overlay directory
+		overlayStorage := &StorageEntity{
+			Dir:           overlay.Dir,
 			ItemIsDir:     o.StorageEntity.ItemIsDir,
 			FileExtension: o.StorageEntity.FileExtension,
 		}
 
-		customNames, customErr := customStorage.GetNames()
-		if customErr == nil {
-			// Add custom patterns, they will override main patterns with same name
-			for _, name := range customNames {
-				nameMap[name] = true
+		overlayNames, overlayErr := overlayStorage.GetNames()
+		if overlayErr == nil {
+			// Add overlay patterns that pass this overlay's filters; they
+			// override main patterns with the same name
+			for _, name := range overlayNames {
+				if overlay.allows(name) {
+					nameMap[name] = true
+				}
 			}
 		}
-		// Ignore errors from custom directory (it might not exist)
+		// Ignore errors from overlay directories (they might not exist)
 	}
 
 	// Convert map keys back to slice
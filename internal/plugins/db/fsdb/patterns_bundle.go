@@ -0,0 +1,208 @@
+package fsdb
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/danielmiessler/fabric/internal/plugins/template"
+)
+
+const tmplSuffix = ".tmpl"
+const fabricIgnoreFile = ".fabricignore"
+
+// RenderedFile is one file inside a pattern bundle, already rendered (for
+// ".tmpl" files) or copied as-is.
+type RenderedFile struct {
+	Path       string
+	Content    string
+	Executable bool
+}
+
+// GetBundle loads a pattern the same way GetApplyVariables does, and
+// additionally walks the pattern's directory for bundle files: any file
+// whose name ends in ".tmpl" is rendered with the same variables and
+// {{input}} sentinel handling, stripped of its ".tmpl" suffix on output,
+// while every other file (besides SystemPatternFile, already represented by
+// Pattern.Pattern, and .fabricignore itself) is copied as-is. A
+// .fabricignore file in the pattern directory lists globs (path.Match
+// semantics, one per line): a trailing "/" marks a directory glob that is
+// not walked into at all, anything else is a file glob that is rendered but
+// not emitted.
+func (o *PatternsEntity) GetBundle(name string, variables map[string]string, input string) (pattern *Pattern, err error) {
+	if pattern, err = o.GetApplyVariables(name, variables, input); err != nil {
+		return nil, err
+	}
+
+	var dir string
+	if dir, err = o.resolvePatternDir(name); err != nil {
+		return nil, err
+	}
+
+	var ignore *fabricIgnore
+	if ignore, err = loadFabricIgnore(dir); err != nil {
+		return nil, err
+	}
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		rel, relErr := filepath.Rel(dir, p)
+		if relErr != nil {
+			return relErr
+		}
+		if rel == "." {
+			return nil
+		}
+		relSlash := filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if ignore.matchesDir(relSlash) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if rel == o.SystemPatternFile || rel == fabricIgnoreFile {
+			return nil
+		}
+
+		content, readErr := os.ReadFile(p)
+		if readErr != nil {
+			return readErr
+		}
+
+		info, infoErr := d.Info()
+		if infoErr != nil {
+			return infoErr
+		}
+
+		outPath := relSlash
+		text := string(content)
+		if strings.HasSuffix(rel, tmplSuffix) {
+			outPath = strings.TrimSuffix(relSlash, tmplSuffix)
+			if text, err = o.renderBundleFile(text, variables, input); err != nil {
+				return err
+			}
+		}
+
+		if ignore.matchesFile(relSlash) {
+			// Rendered (for validation/side effects) but not emitted.
+			return nil
+		}
+
+		pattern.Files = append(pattern.Files, RenderedFile{
+			Path:       outPath,
+			Content:    text,
+			Executable: info.Mode()&0o111 != 0,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pattern.Files, func(i, j int) bool { return pattern.Files[i].Path < pattern.Files[j].Path })
+	return pattern, nil
+}
+
+// renderBundleFile applies Fabric's variable, include, and {{input}}
+// sentinel handling to a single bundle file, without ensureInput's
+// main-pattern-only behavior of appending a trailing {{input}}.
+func (o *PatternsEntity) renderBundleFile(content string, variables map[string]string, input string) (rendered string, err error) {
+	withSentinel := strings.ReplaceAll(content, "{{input}}", inputSentinel)
+
+	var withIncludes string
+	if withIncludes, err = o.expandIncludes(withSentinel, variables, input, nil); err != nil {
+		return "", err
+	}
+
+	var processed string
+	if processed, err = template.ApplyTemplate(withIncludes, variables, input); err != nil {
+		return "", err
+	}
+
+	return strings.ReplaceAll(processed, inputSentinel, input), nil
+}
+
+// resolvePatternDir finds the directory backing pattern name, walking
+// overlays the same way getFromDB does.
+func (o *PatternsEntity) resolvePatternDir(name string) (dir string, err error) {
+	for _, overlay := range o.effectiveOverlays() {
+		if !overlay.allows(name) {
+			continue
+		}
+		candidate := filepath.Join(overlay.Dir, name)
+		if info, statErr := os.Stat(candidate); statErr == nil && info.IsDir() {
+			dir = candidate
+		}
+	}
+	if dir != "" {
+		return dir, nil
+	}
+
+	candidate := filepath.Join(o.Dir, name)
+	if info, statErr := os.Stat(candidate); statErr == nil && info.IsDir() {
+		return candidate, nil
+	}
+
+	return "", &PatternNotFoundError{Name: name}
+}
+
+// fabricIgnore holds the directory- and file-level globs parsed from a
+// pattern bundle's .fabricignore.
+type fabricIgnore struct {
+	dirGlobs  []string
+	fileGlobs []string
+}
+
+// loadFabricIgnore reads dir/.fabricignore if present. A line ending in "/"
+// is a directory glob (not walked into at all); anything else is a file glob
+// (rendered but not emitted).
+func loadFabricIgnore(dir string) (*fabricIgnore, error) {
+	data, err := os.ReadFile(filepath.Join(dir, fabricIgnoreFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &fabricIgnore{}, nil
+		}
+		return nil, fmt.Errorf("could not read %s: %v", fabricIgnoreFile, err)
+	}
+
+	fi := &fabricIgnore{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if dirGlob, ok := strings.CutSuffix(line, "/"); ok {
+			fi.dirGlobs = append(fi.dirGlobs, dirGlob)
+		} else {
+			fi.fileGlobs = append(fi.fileGlobs, line)
+		}
+	}
+	return fi, nil
+}
+
+func (fi *fabricIgnore) matchesDir(rel string) bool {
+	for _, glob := range fi.dirGlobs {
+		if ok, _ := path.Match(glob, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (fi *fabricIgnore) matchesFile(rel string) bool {
+	for _, glob := range fi.fileGlobs {
+		if ok, _ := path.Match(glob, rel); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package fsdb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func findBundleFile(files []RenderedFile, path string) (RenderedFile, bool) {
+	for _, f := range files {
+		if f.Path == path {
+			return f, true
+		}
+	}
+	return RenderedFile{}, false
+}
+
+func TestGetBundleRendersTmplFilesAndStripsSuffix(t *testing.T) {
+	dir := t.TempDir()
+	writePatternFile(t, dir, "scaffold", "system.md", "main prompt\n{{input}}")
+	writePatternFile(t, dir, "scaffold", "docs/readme.md.tmpl", "# hello {{input}}")
+
+	entity := &PatternsEntity{
+		StorageEntity:     &StorageEntity{Dir: dir, ItemIsDir: true},
+		SystemPatternFile: "system.md",
+	}
+
+	bundle, err := entity.GetBundle("scaffold", nil, "world")
+	if err != nil {
+		t.Fatalf("GetBundle: %v", err)
+	}
+
+	file, ok := findBundleFile(bundle.Files, "docs/readme.md")
+	if !ok {
+		t.Fatalf("Files = %v, want a docs/readme.md entry", bundle.Files)
+	}
+	if file.Content != "# hello world" {
+		t.Errorf("Content = %q, want %q", file.Content, "# hello world")
+	}
+	if _, ok := findBundleFile(bundle.Files, "docs/readme.md.tmpl"); ok {
+		t.Error(".tmpl suffix should be stripped from the emitted path")
+	}
+}
+
+func TestGetBundleSkipsIgnoredDirectoryEntirely(t *testing.T) {
+	dir := t.TempDir()
+	writePatternFile(t, dir, "scaffold", "system.md", "main prompt\n{{input}}")
+	writePatternFile(t, dir, "scaffold", "vendor/thirdparty.md", "not ours")
+	writePatternFile(t, dir, "scaffold", ".fabricignore", "vendor/\n")
+
+	entity := &PatternsEntity{
+		StorageEntity:     &StorageEntity{Dir: dir, ItemIsDir: true},
+		SystemPatternFile: "system.md",
+	}
+
+	bundle, err := entity.GetBundle("scaffold", nil, "world")
+	if err != nil {
+		t.Fatalf("GetBundle: %v", err)
+	}
+
+	if _, ok := findBundleFile(bundle.Files, "vendor/thirdparty.md"); ok {
+		t.Error("vendor/ is directory-ignored and should never be walked into")
+	}
+}
+
+func TestGetBundleOmitsFileIgnoredFiles(t *testing.T) {
+	dir := t.TempDir()
+	writePatternFile(t, dir, "scaffold", "system.md", "main prompt\n{{input}}")
+	writePatternFile(t, dir, "scaffold", "draft.md", "not ready yet")
+	writePatternFile(t, dir, "scaffold", ".fabricignore", "draft.md\n")
+
+	entity := &PatternsEntity{
+		StorageEntity:     &StorageEntity{Dir: dir, ItemIsDir: true},
+		SystemPatternFile: "system.md",
+	}
+
+	bundle, err := entity.GetBundle("scaffold", nil, "world")
+	if err != nil {
+		t.Fatalf("GetBundle: %v", err)
+	}
+
+	if _, ok := findBundleFile(bundle.Files, "draft.md"); ok {
+		t.Error("draft.md is file-ignored and should not be emitted")
+	}
+
+	// Sanity check the file is really still on disk: ignoring it only keeps
+	// it out of the bundle output.
+	if _, err := os.Stat(filepath.Join(dir, "scaffold", "draft.md")); err != nil {
+		t.Fatalf("draft.md should still exist on disk: %v", err)
+	}
+}
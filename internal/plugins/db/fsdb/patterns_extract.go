@@ -0,0 +1,228 @@
+package fsdb
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// PatternOrigin identifies where a pattern was resolved from. This tree has
+// no embedded/compiled-in pattern set distinct from the main patterns
+// directory (patterns are installed into Dir, not go:embed'd), so OriginMain
+// covers both "module-shipped" and "main Dir" patterns; there is nothing for
+// a separate builtin value to distinguish here.
+type PatternOrigin string
+
+const (
+	OriginMain    PatternOrigin = "main"
+	OriginCustom  PatternOrigin = "custom"
+	OriginOverlay PatternOrigin = "overlay"
+)
+
+// PatternSource describes where a known pattern name currently resolves to on
+// disk.
+type PatternSource struct {
+	Name    string
+	Origin  PatternOrigin
+	AbsPath string
+}
+
+// ExtractOptions controls how Extract handles destination conflicts.
+type ExtractOptions struct {
+	// Overwrite replaces an existing file at the destination.
+	Overwrite bool
+	// Rename preserves an existing file at the destination by appending
+	// ".orig" to it before writing the extracted pattern in its place.
+	Rename bool
+}
+
+// ExtractReport summarizes the outcome of an Extract call. Entries are
+// "name" for a single-file pattern's SystemPatternFile, or
+// "name/relative/path" for any other file inside a pattern bundle.
+type ExtractReport struct {
+	Extracted []string
+	Skipped   []string
+	// Renamed maps an extracted file entry to the path its pre-existing
+	// destination file was moved to, for files extracted with
+	// ExtractOptions.Rename.
+	Renamed map[string]string
+}
+
+// EnumerateSources lists every known pattern name along with where it
+// currently resolves from: the built-in/main patterns directory, the legacy
+// CustomPatternsDir shim, or one of the ordered Overlays.
+func (o *PatternsEntity) EnumerateSources() (sources []PatternSource, err error) {
+	var names []string
+	if names, err = o.GetNames(); err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		source, sourceErr := o.resolveSource(name)
+		if sourceErr != nil {
+			// A name from GetNames that can't be resolved to a file on disk
+			// (e.g. a race with a concurrent delete) is skipped rather than
+			// failing the whole enumeration.
+			continue
+		}
+		sources = append(sources, source)
+	}
+	return
+}
+
+// resolveSource finds the file that getFromDB would read for name, and
+// reports which layer it came from.
+func (o *PatternsEntity) resolveSource(name string) (source PatternSource, err error) {
+	for _, overlay := range o.effectiveOverlays() {
+		if !overlay.allows(name) {
+			continue
+		}
+		overlayPatternPath := filepath.Join(overlay.Dir, name, o.SystemPatternFile)
+		if _, statErr := os.Stat(overlayPatternPath); statErr == nil {
+			origin := OriginOverlay
+			if overlay.Dir == o.CustomPatternsDir && len(o.Overlays) == 0 {
+				origin = OriginCustom
+			}
+			source = PatternSource{Name: name, Origin: origin, AbsPath: overlayPatternPath}
+		}
+	}
+	if source.AbsPath != "" {
+		return source, nil
+	}
+
+	mainPatternPath := filepath.Join(o.Dir, name, o.SystemPatternFile)
+	if _, statErr := os.Stat(mainPatternPath); statErr != nil {
+		return PatternSource{}, &PatternNotFoundError{Name: name}
+	}
+	return PatternSource{Name: name, Origin: OriginMain, AbsPath: mainPatternPath}, nil
+}
+
+// Extract copies the named patterns (each of which may be a literal name or a
+// glob, per resolveGlobNames) into dest, one subdirectory per pattern, mainly
+// so users can fork built-ins into their own CustomPatternsDir for local
+// tweaks. A pattern that is a multi-file bundle (see GetBundle) has every one
+// of its files copied as-is, except directories matched by its
+// .fabricignore directory-skip globs, which are not walked into at all (the
+// same as GetBundle itself never emits them).
+func (o *PatternsEntity) Extract(names []string, dest string, opts ExtractOptions) (report ExtractReport, err error) {
+	var resolved []string
+	if resolved, err = o.resolveExtractNames(names); err != nil {
+		return ExtractReport{}, err
+	}
+
+	report.Renamed = make(map[string]string)
+	for _, name := range resolved {
+		var sourceDir string
+		if sourceDir, err = o.resolvePatternDir(name); err != nil {
+			return ExtractReport{}, err
+		}
+
+		var ignore *fabricIgnore
+		if ignore, err = loadFabricIgnore(sourceDir); err != nil {
+			return ExtractReport{}, err
+		}
+
+		destDir := filepath.Join(dest, name)
+
+		walkErr := filepath.WalkDir(sourceDir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			rel, relErr := filepath.Rel(sourceDir, p)
+			if relErr != nil {
+				return relErr
+			}
+			if rel == "." {
+				return nil
+			}
+			relSlash := filepath.ToSlash(rel)
+
+			if d.IsDir() {
+				if ignore.matchesDir(relSlash) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			return o.extractFile(name, rel, p, filepath.Join(destDir, rel), opts, &report)
+		})
+		if walkErr != nil {
+			return ExtractReport{}, walkErr
+		}
+	}
+
+	return report, nil
+}
+
+// extractFile copies one source file to destPath, applying opts' conflict
+// handling and recording the outcome under an entry label in report: "name"
+// for the pattern's SystemPatternFile, or "name/rel" for any other bundle
+// file.
+func (o *PatternsEntity) extractFile(
+	name, rel, sourcePath, destPath string, opts ExtractOptions, report *ExtractReport) error {
+
+	entry := name
+	if rel != o.SystemPatternFile {
+		entry = name + "/" + filepath.ToSlash(rel)
+	}
+
+	if _, statErr := os.Stat(destPath); statErr == nil {
+		switch {
+		case opts.Rename:
+			origPath := destPath + ".orig"
+			if renameErr := os.Rename(destPath, origPath); renameErr != nil {
+				return fmt.Errorf("could not preserve existing file %q: %v", entry, renameErr)
+			}
+			report.Renamed[entry] = origPath
+		case opts.Overwrite:
+			// fall through and overwrite below
+		default:
+			report.Skipped = append(report.Skipped, entry)
+			return nil
+		}
+	}
+
+	content, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return fmt.Errorf("could not read file %q: %v", entry, err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(destPath), os.ModePerm); err != nil {
+		return fmt.Errorf("could not create destination directory for %q: %v", entry, err)
+	}
+	if err = os.WriteFile(destPath, content, 0644); err != nil {
+		return fmt.Errorf("could not write file %q: %v", entry, err)
+	}
+
+	report.Extracted = append(report.Extracted, entry)
+	return nil
+}
+
+// resolveExtractNames expands any glob entries in names (via resolveGlobNames)
+// and returns the deduplicated, sorted union of literal pattern names.
+func (o *PatternsEntity) resolveExtractNames(names []string) (resolved []string, err error) {
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if isGlobSource(name) {
+			var matches []string
+			if matches, err = o.resolveGlobNames(name); err != nil {
+				return nil, err
+			}
+			for _, match := range matches {
+				seen[match] = true
+			}
+			continue
+		}
+		seen[name] = true
+	}
+
+	resolved = make([]string, 0, len(seen))
+	for name := range seen {
+		resolved = append(resolved, name)
+	}
+	sort.Strings(resolved)
+	return
+}
@@ -0,0 +1,51 @@
+package fsdb
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestExtractCopiesFullBundle(t *testing.T) {
+	mainDir := t.TempDir()
+	dest := t.TempDir()
+
+	writePatternFile(t, mainDir, "scaffold", "system.md", "main prompt")
+	writePatternFile(t, mainDir, "scaffold", "docs/readme.md.tmpl", "# {{name}}")
+	writePatternFile(t, mainDir, "scaffold", "skip/ignored.md", "should not be walked")
+	writePatternFile(t, mainDir, "scaffold", ".fabricignore", "skip/\n")
+
+	entity := &PatternsEntity{
+		StorageEntity:     &StorageEntity{Dir: mainDir, ItemIsDir: true},
+		SystemPatternFile: "system.md",
+	}
+
+	report, err := entity.Extract([]string{"scaffold"}, dest, ExtractOptions{})
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	sort.Strings(report.Extracted)
+	want := []string{"scaffold", "scaffold/.fabricignore", "scaffold/docs/readme.md.tmpl"}
+	if len(report.Extracted) != len(want) {
+		t.Fatalf("Extracted = %v, want %v", report.Extracted, want)
+	}
+	for i, w := range want {
+		if report.Extracted[i] != w {
+			t.Errorf("Extracted[%d] = %q, want %q", i, report.Extracted[i], w)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dest, "scaffold", "skip", "ignored.md")); !os.IsNotExist(err) {
+		t.Errorf("expected skip/ignored.md to not be extracted, stat err = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dest, "scaffold", "docs", "readme.md.tmpl"))
+	if err != nil {
+		t.Fatalf("reading extracted bundle file: %v", err)
+	}
+	if string(content) != "# {{name}}" {
+		t.Errorf("bundle files are copied as-is, got %q", string(content))
+	}
+}
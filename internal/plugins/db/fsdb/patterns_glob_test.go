@@ -0,0 +1,109 @@
+package fsdb
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGlobMatchSegments(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"analyze_*", "analyze_claims", true},
+		{"analyze_*", "summarize", false},
+		// A single "*" segment must not cross "/", unlike "**".
+		{"write/*", "write/foo/bar", false},
+		{"write/**", "write/foo/bar", true},
+		{"write/**", "write", true},
+		{"write/**", "other", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.name, func(t *testing.T) {
+			got, err := globMatchSegments(strings.Split(tt.pattern, "/"), strings.Split(tt.name, "/"))
+			if err != nil {
+				t.Fatalf("globMatchSegments(%q, %q) error: %v", tt.pattern, tt.name, err)
+			}
+			if got != tt.want {
+				t.Errorf("globMatchSegments(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveGlobNamesDotDotDotIncludesBarePrefix(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"summarize", "summarizer"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+
+	entity := &PatternsEntity{StorageEntity: &StorageEntity{Dir: dir, ItemIsDir: true}}
+
+	matches, err := entity.resolveGlobNames("summarize...")
+	if err != nil {
+		t.Fatalf("resolveGlobNames: %v", err)
+	}
+
+	if len(matches) != 1 || matches[0] != "summarize" {
+		t.Fatalf("matches = %v, want [summarize]", matches)
+	}
+}
+
+func TestGetApplyVariablesResolvesSingleMatchFileGlob(t *testing.T) {
+	dir := t.TempDir()
+	patternPath := filepath.Join(dir, "ad-hoc.md")
+	if err := os.WriteFile(patternPath, []byte("hello {{input}}"), 0o644); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	entity := &PatternsEntity{StorageEntity: &StorageEntity{Dir: t.TempDir(), ItemIsDir: true}}
+
+	source := filepath.Join(dir, "*.md")
+	pattern, err := entity.GetApplyVariables(source, nil, "world")
+	if err != nil {
+		t.Fatalf("GetApplyVariables(%q): %v", source, err)
+	}
+	if pattern.Name != patternPath {
+		t.Errorf("Name = %q, want %q", pattern.Name, patternPath)
+	}
+}
+
+func TestGetWithoutVariablesResolvesSingleMatchFileGlob(t *testing.T) {
+	dir := t.TempDir()
+	patternPath := filepath.Join(dir, "ad-hoc.md")
+	if err := os.WriteFile(patternPath, []byte("hello {{input}}"), 0o644); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	entity := &PatternsEntity{StorageEntity: &StorageEntity{Dir: t.TempDir(), ItemIsDir: true}}
+
+	source := filepath.Join(dir, "*.md")
+	pattern, err := entity.GetWithoutVariables(source, "world")
+	if err != nil {
+		t.Fatalf("GetWithoutVariables(%q): %v", source, err)
+	}
+	if pattern.Pattern != "hello world" {
+		t.Errorf("Pattern = %q, want %q", pattern.Pattern, "hello world")
+	}
+}
+
+func TestGetApplyVariablesFileGlobNoMatch(t *testing.T) {
+	dir := t.TempDir()
+
+	entity := &PatternsEntity{StorageEntity: &StorageEntity{Dir: t.TempDir(), ItemIsDir: true}}
+
+	source := filepath.Join(dir, "*.md")
+	_, err := entity.GetApplyVariables(source, nil, "world")
+	if err == nil {
+		t.Fatal("expected a NoPatternMatchError, got nil")
+	}
+	if _, ok := err.(*NoPatternMatchError); !ok {
+		t.Errorf("error = %T, want *NoPatternMatchError", err)
+	}
+}
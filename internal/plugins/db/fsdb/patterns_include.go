@@ -0,0 +1,86 @@
+package fsdb
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/danielmiessler/fabric/internal/plugins/template"
+)
+
+// maxIncludeDepth bounds {{include "..."}} recursion so a cycle fails fast
+// with a clear message instead of recursing forever.
+const maxIncludeDepth = 16
+
+// includeDirective matches the Fabric-specific {{include "other_pattern"}}
+// extension, which is expanded before the pattern is handed to the generic
+// template engine.
+var includeDirective = regexp.MustCompile(`\{\{include\s+"([^"]*)"\s*\}\}`)
+
+// expandIncludes replaces every {{include "name"}} directive in content with
+// the fully rendered output of pattern name, loaded through the same
+// PatternsEntity and inheriting variables and input. stack is the chain of
+// pattern names currently being included, used to detect cycles.
+func (o *PatternsEntity) expandIncludes(
+	content string, variables map[string]string, input string, stack []string) (result string, err error) {
+
+	matches := includeDirective.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content, nil
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		name := content[m[2]:m[3]]
+
+		b.WriteString(content[last:start])
+
+		var rendered string
+		if rendered, err = o.renderInclude(name, variables, input, stack); err != nil {
+			return "", err
+		}
+		b.WriteString(rendered)
+
+		last = end
+	}
+	b.WriteString(content[last:])
+
+	return b.String(), nil
+}
+
+// renderInclude loads and fully renders the pattern named name for inclusion
+// into a parent pattern, applying variables and recursively expanding any
+// includes it contains.
+func (o *PatternsEntity) renderInclude(
+	name string, variables map[string]string, input string, stack []string) (rendered string, err error) {
+
+	for _, seen := range stack {
+		if seen == name {
+			return "", fmt.Errorf("include cycle detected: %s -> %s", strings.Join(append(stack, name), " -> "), name)
+		}
+	}
+	if len(stack) >= maxIncludeDepth {
+		return "", fmt.Errorf("include depth exceeded %d while including %q", maxIncludeDepth, name)
+	}
+
+	var pattern *Pattern
+	if pattern, err = o.getFromDB(name); err != nil {
+		return "", fmt.Errorf("include %q: %w", name, err)
+	}
+
+	withSentinel := strings.ReplaceAll(pattern.Pattern, "{{input}}", inputSentinel)
+
+	var withIncludes string
+	if withIncludes, err = o.expandIncludes(withSentinel, variables, input, append(stack, name)); err != nil {
+		return "", err
+	}
+
+	var processed string
+	if processed, err = template.ApplyTemplate(withIncludes, variables, input); err != nil {
+		return "", err
+	}
+
+	return strings.ReplaceAll(processed, inputSentinel, input), nil
+}
@@ -0,0 +1,53 @@
+package fsdb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExpandIncludesDetectsCycle(t *testing.T) {
+	dir := t.TempDir()
+	writePatternFile(t, dir, "a", "system.md", `before {{include "b"}} after`)
+	writePatternFile(t, dir, "b", "system.md", `loop {{include "a"}}`)
+
+	entity := &PatternsEntity{
+		StorageEntity:     &StorageEntity{Dir: dir, ItemIsDir: true},
+		SystemPatternFile: "system.md",
+	}
+
+	_, err := entity.GetApplyVariables("a", nil, "")
+	if err == nil {
+		t.Fatal("expected an include cycle error, got nil")
+	}
+	if !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("error %q does not mention a cycle", err.Error())
+	}
+}
+
+func TestExpandIncludesEnforcesDepthLimit(t *testing.T) {
+	dir := t.TempDir()
+
+	// A straight-line chain of maxIncludeDepth+2 patterns, none of which
+	// repeats, so it only fails because it's too deep, not because of a
+	// cycle.
+	for i := 0; i < maxIncludeDepth+2; i++ {
+		name := fmt.Sprintf("chain%d", i)
+		next := fmt.Sprintf("chain%d", i+1)
+		writePatternFile(t, dir, name, "system.md", fmt.Sprintf(`{{include "%s"}}`, next))
+	}
+	writePatternFile(t, dir, fmt.Sprintf("chain%d", maxIncludeDepth+2), "system.md", "leaf")
+
+	entity := &PatternsEntity{
+		StorageEntity:     &StorageEntity{Dir: dir, ItemIsDir: true},
+		SystemPatternFile: "system.md",
+	}
+
+	_, err := entity.GetApplyVariables("chain0", nil, "")
+	if err == nil {
+		t.Fatal("expected a depth-exceeded error, got nil")
+	}
+	if !strings.Contains(err.Error(), "depth") {
+		t.Errorf("error %q does not mention depth", err.Error())
+	}
+}
@@ -0,0 +1,73 @@
+package fsdb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePatternFile(t *testing.T, dir, name, relPath, content string) {
+	t.Helper()
+	full := filepath.Join(dir, name, relPath)
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+}
+
+func TestResolveSourceOrigin(t *testing.T) {
+	mainDir := t.TempDir()
+	customDir := t.TempDir()
+	overlayDir := t.TempDir()
+
+	writePatternFile(t, mainDir, "only_main", "system.md", "main")
+	writePatternFile(t, customDir, "custom_only", "system.md", "custom")
+	writePatternFile(t, overlayDir, "overlay_only", "system.md", "overlay")
+
+	tests := []struct {
+		name   string
+		entity *PatternsEntity
+		want   PatternOrigin
+	}{
+		{
+			name: "only_main",
+			entity: &PatternsEntity{
+				StorageEntity:     &StorageEntity{Dir: mainDir, ItemIsDir: true},
+				SystemPatternFile: "system.md",
+			},
+			want: OriginMain,
+		},
+		{
+			name: "custom_only",
+			entity: &PatternsEntity{
+				StorageEntity:     &StorageEntity{Dir: mainDir, ItemIsDir: true},
+				SystemPatternFile: "system.md",
+				CustomPatternsDir: customDir,
+			},
+			want: OriginCustom,
+		},
+		{
+			name: "overlay_only",
+			entity: &PatternsEntity{
+				StorageEntity:     &StorageEntity{Dir: mainDir, ItemIsDir: true},
+				SystemPatternFile: "system.md",
+				Overlays:          []Overlay{{Dir: overlayDir}},
+			},
+			want: OriginOverlay,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := tt.entity.resolveSource(tt.name)
+			if err != nil {
+				t.Fatalf("resolveSource(%q): %v", tt.name, err)
+			}
+			if source.Origin != tt.want {
+				t.Errorf("Origin = %q, want %q", source.Origin, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package fsdb
+
+import (
+	"testing"
+)
+
+func TestGetFromDBOverlayOrdering(t *testing.T) {
+	mainDir := t.TempDir()
+	teamDir := t.TempDir()
+	localDir := t.TempDir()
+
+	writePatternFile(t, mainDir, "shared", "system.md", "main version")
+	writePatternFile(t, teamDir, "shared", "system.md", "team version")
+	writePatternFile(t, teamDir, "hidden", "system.md", "team hidden")
+	writePatternFile(t, localDir, "shared", "system.md", "local version")
+
+	tests := []struct {
+		name     string
+		overlays []Overlay
+		pattern  string
+		want     string
+	}{
+		{
+			name:     "later overlay wins",
+			overlays: []Overlay{{Dir: teamDir}, {Dir: localDir}},
+			pattern:  "shared",
+			want:     "local version",
+		},
+		{
+			name:     "earlier overlay wins when later is excluded",
+			overlays: []Overlay{{Dir: teamDir}, {Dir: localDir, ExcludePatterns: []string{"shared"}}},
+			pattern:  "shared",
+			want:     "team version",
+		},
+		{
+			name:     "falls back to main when no overlay include matches",
+			overlays: []Overlay{{Dir: teamDir, IncludePatterns: []string{"hidden"}}},
+			pattern:  "shared",
+			want:     "main version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := &PatternsEntity{
+				StorageEntity:     &StorageEntity{Dir: mainDir, ItemIsDir: true},
+				SystemPatternFile: "system.md",
+				Overlays:          tt.overlays,
+			}
+
+			pattern, err := entity.getFromDB(tt.pattern)
+			if err != nil {
+				t.Fatalf("getFromDB(%q): %v", tt.pattern, err)
+			}
+			if pattern.Pattern != tt.want {
+				t.Errorf("Pattern = %q, want %q", pattern.Pattern, tt.want)
+			}
+		})
+	}
+}
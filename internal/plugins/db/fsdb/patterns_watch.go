@@ -0,0 +1,212 @@
+package fsdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ChangeEvent describes the kind of change a pattern listener was notified of.
+type ChangeEvent string
+
+const (
+	PatternCreated  ChangeEvent = "created"
+	PatternModified ChangeEvent = "modified"
+	PatternDeleted  ChangeEvent = "deleted"
+)
+
+// patternWatcher fans out fsnotify events across every overlay root (plus the
+// main Dir) to the subscribers registered on a PatternsEntity.
+type patternWatcher struct {
+	fs    *fsnotify.Watcher
+	roots []string
+
+	mu          sync.Mutex
+	nextID      int
+	subscribers map[int]func(name string, event ChangeEvent)
+}
+
+// Subscribe registers fn to be called with the pattern name and the kind of
+// change whenever a pattern file is created, modified, or deleted under Dir or
+// any overlay root. It only has an effect when LiveReload is true; it starts
+// the underlying watcher on first use and returns an unsubscribe function.
+// Call Close when done watching (e.g. on server shutdown) to release the
+// underlying OS watch handles and stop its goroutine; unsubscribing every
+// listener does not do that on its own.
+func (o *PatternsEntity) Subscribe(fn func(name string, event ChangeEvent)) (unsubscribe func(), err error) {
+	if !o.LiveReload {
+		return func() {}, nil
+	}
+
+	if o.watcher == nil {
+		if o.watcher, err = o.startWatcher(); err != nil {
+			return nil, err
+		}
+	}
+
+	pw := o.watcher
+	pw.mu.Lock()
+	id := pw.nextID
+	pw.nextID++
+	pw.subscribers[id] = fn
+	pw.mu.Unlock()
+
+	unsubscribe = func() {
+		pw.mu.Lock()
+		delete(pw.subscribers, id)
+		pw.mu.Unlock()
+	}
+	return unsubscribe, nil
+}
+
+// Close stops the watcher started by Subscribe, releasing its underlying OS
+// watch handles and terminating its goroutine. It is a no-op if Subscribe was
+// never called (or never started a watcher because LiveReload was false).
+func (o *PatternsEntity) Close() error {
+	if o.watcher == nil {
+		return nil
+	}
+	err := o.watcher.fs.Close()
+	o.watcher = nil
+	return err
+}
+
+// startWatcher watches Dir and every overlay root for changes, dispatching
+// create/modify/delete events (keyed by pattern name, the directory
+// immediately under the watched root) to all current subscribers.
+//
+// fsnotify/inotify watches are not recursive, so watching a root alone only
+// ever sees events on the root's direct children (a pattern directory being
+// created/renamed/removed) and never a write to Dir/<name>/SystemPatternFile.
+// Every existing pattern directory is watched individually up front, and
+// run() adds a watch on any pattern directory created later.
+func (o *PatternsEntity) startWatcher() (*patternWatcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("could not start pattern watcher: %v", err)
+	}
+
+	roots := []string{o.Dir}
+	for _, overlay := range o.effectiveOverlays() {
+		roots = append(roots, overlay.Dir)
+	}
+
+	pw := &patternWatcher{fs: fsw, roots: roots, subscribers: make(map[int]func(string, ChangeEvent))}
+	for _, root := range roots {
+		pw.watchRoot(root)
+	}
+
+	go pw.run()
+
+	return pw, nil
+}
+
+// watchRoot adds a watch on root itself and on every pattern directory
+// already inside it. Errors are ignored: a root that doesn't exist yet (or
+// no longer does) simply contributes no events until it does.
+func (pw *patternWatcher) watchRoot(root string) {
+	if root == "" {
+		return
+	}
+	_ = pw.fs.Add(root)
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			_ = pw.fs.Add(filepath.Join(root, entry.Name()))
+		}
+	}
+}
+
+func (pw *patternWatcher) run() {
+	for {
+		select {
+		case event, ok := <-pw.fs.Events:
+			if !ok {
+				return
+			}
+			name := patternNameFromPath(pw.roots, event.Name)
+			if name == "" {
+				continue
+			}
+			if event.Op&fsnotify.Create != 0 && isDirectChildOfRoot(pw.roots, event.Name) {
+				if info, statErr := os.Stat(event.Name); statErr == nil && info.IsDir() {
+					// A new pattern directory appeared; watch it too, so
+					// later edits to the files inside it are seen.
+					_ = pw.fs.Add(event.Name)
+				}
+			}
+			pw.notify(name, changeEventFor(event.Op))
+		case _, ok := <-pw.fs.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// isDirectChildOfRoot reports whether eventPath is exactly one path
+// component below one of roots, i.e. a pattern directory itself rather than
+// a file further inside one.
+func isDirectChildOfRoot(roots []string, eventPath string) bool {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, eventPath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if !strings.ContainsRune(rel, filepath.Separator) {
+			return true
+		}
+	}
+	return false
+}
+
+func (pw *patternWatcher) notify(name string, event ChangeEvent) {
+	pw.mu.Lock()
+	subscribers := make([]func(string, ChangeEvent), 0, len(pw.subscribers))
+	for _, fn := range pw.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	pw.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(name, event)
+	}
+}
+
+// patternNameFromPath maps an absolute event path to the pattern name it
+// belongs to, i.e. the path component directly under whichever watched root
+// contains it. It returns "" for events outside every root and for events on
+// a root directory itself.
+func patternNameFromPath(roots []string, eventPath string) string {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, eventPath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		parts := strings.Split(rel, string(filepath.Separator))
+		if len(parts) == 0 || parts[0] == "." {
+			continue
+		}
+		return parts[0]
+	}
+	return ""
+}
+
+func changeEventFor(op fsnotify.Op) ChangeEvent {
+	switch {
+	case op&fsnotify.Create != 0:
+		return PatternCreated
+	case op&fsnotify.Remove != 0, op&fsnotify.Rename != 0:
+		return PatternDeleted
+	default:
+		return PatternModified
+	}
+}
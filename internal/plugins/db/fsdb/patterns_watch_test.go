@@ -0,0 +1,140 @@
+package fsdb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type watchEvent struct {
+	name  string
+	event ChangeEvent
+}
+
+func waitForWatchEvent(t *testing.T, events chan watchEvent, want string) watchEvent {
+	t.Helper()
+	select {
+	case got := <-events:
+		if got.name != want {
+			t.Fatalf("name = %q, want %q", got.name, want)
+		}
+		return got
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for a notification for %q", want)
+		return watchEvent{}
+	}
+}
+
+func newWatchedEntity(t *testing.T, dir string) (*PatternsEntity, chan watchEvent, func()) {
+	t.Helper()
+
+	entity := &PatternsEntity{
+		StorageEntity:     &StorageEntity{Dir: dir, ItemIsDir: true},
+		SystemPatternFile: "system.md",
+		LiveReload:        true,
+	}
+
+	events := make(chan watchEvent, 8)
+	unsubscribe, err := entity.Subscribe(func(name string, event ChangeEvent) {
+		events <- watchEvent{name: name, event: event}
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Subscribe adds the watches before returning; give the watcher goroutine
+	// a moment to start reading events before the test starts mutating the
+	// filesystem.
+	time.Sleep(50 * time.Millisecond)
+
+	teardown := func() {
+		unsubscribe()
+		if err := entity.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	}
+	return entity, events, teardown
+}
+
+func TestPatternWatcherNotifiesOnExistingPatternFileEdit(t *testing.T) {
+	dir := t.TempDir()
+	patternDir := filepath.Join(dir, "existing")
+	if err := os.MkdirAll(patternDir, 0o755); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	patternFile := filepath.Join(patternDir, "system.md")
+	if err := os.WriteFile(patternFile, []byte("hello"), 0o644); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	_, events, teardown := newWatchedEntity(t, dir)
+	defer teardown()
+
+	if err := os.WriteFile(patternFile, []byte("hello again"), 0o644); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+
+	waitForWatchEvent(t, events, "existing")
+}
+
+func TestPatternWatcherNotifiesOnNewPatternDirectoryAndItsFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	_, events, teardown := newWatchedEntity(t, dir)
+	defer teardown()
+
+	newDir := filepath.Join(dir, "fresh")
+	if err := os.MkdirAll(newDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	// The directory-create notification means the dynamically-added watch on
+	// "fresh" is now in place, so a subsequent write to a file inside it
+	// must also be reported.
+	waitForWatchEvent(t, events, "fresh")
+
+	if err := os.WriteFile(filepath.Join(newDir, "system.md"), []byte("hi"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	waitForWatchEvent(t, events, "fresh")
+}
+
+func TestPatternsEntityCloseStopsWatcherAndIsIdempotent(t *testing.T) {
+	dir := t.TempDir()
+	patternDir := filepath.Join(dir, "existing")
+	if err := os.MkdirAll(patternDir, 0o755); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	patternFile := filepath.Join(patternDir, "system.md")
+	if err := os.WriteFile(patternFile, []byte("hello"), 0o644); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	entity, events, teardown := newWatchedEntity(t, dir)
+	defer teardown()
+
+	if err := entity.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	// Close should release the underlying watcher so Subscribe starts a fresh
+	// one rather than reusing a closed fsnotify.Watcher.
+	if entity.watcher != nil {
+		t.Fatal("Close should nil out entity.watcher")
+	}
+	// Calling Close again (e.g. from a deferred teardown) must not panic or error.
+	if err := entity.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+
+	if err := os.WriteFile(patternFile, []byte("hello again"), 0o644); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+
+	select {
+	case got := <-events:
+		t.Fatalf("got notification %+v after Close, want none", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+}